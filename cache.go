@@ -0,0 +1,102 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// applyConditionalHeaders attaches If-None-Match / If-Modified-Since headers
+// from the cache to req so the server can reply 304 Not Modified instead of
+// resending an unchanged body.
+func (c *URLChecker) applyConditionalHeaders(req *http.Request, rawURL string) {
+	if c.cache == nil {
+		return
+	}
+	if entry, ok := c.cache.get(rawURL); ok {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+}
+
+// cacheEntry is the on-disk representation of a cached response, keyed by
+// URL, so repeated batch runs can issue conditional GETs instead of
+// re-downloading bodies that haven't changed.
+type cacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	Body         []byte    `json:"body"`
+	StatusCode   int       `json:"status_code"`
+	StoredAt     time.Time `json:"stored_at"`
+}
+
+// responseCache stores response bodies and validators under a directory,
+// one JSON file per URL.
+type responseCache struct {
+	dir string
+	ttl time.Duration
+}
+
+// newResponseCache creates a responseCache rooted at dir, creating the
+// directory if needed. A ttl of 0 disables time-based expiry.
+func newResponseCache(dir string, ttl time.Duration) (*responseCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &responseCache{dir: dir, ttl: ttl}, nil
+}
+
+// keyFor returns the cache filename for a URL.
+func (c *responseCache) keyFor(rawURL string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// get loads the cache entry for a URL, returning ok=false if absent or
+// expired per the configured TTL.
+func (c *responseCache) get(rawURL string) (entry cacheEntry, ok bool) {
+	data, err := os.ReadFile(c.keyFor(rawURL))
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return cacheEntry{}, false
+	}
+	if c.ttl > 0 && time.Since(entry.StoredAt) > c.ttl {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// put writes (or overwrites) the cache entry for a URL.
+func (c *responseCache) put(entry cacheEntry) error {
+	entry.StoredAt = time.Now()
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.keyFor(entry.URL), data, 0644)
+}
+
+// purge removes all entries under the cache directory.
+func (c *responseCache) purge() (int, error) {
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return 0, err
+	}
+	for _, f := range files {
+		if err := os.Remove(f); err != nil {
+			return 0, err
+		}
+	}
+	return len(files), nil
+}
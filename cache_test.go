@@ -0,0 +1,175 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestResponseCachePutGetRoundtrip(t *testing.T) {
+	dir := t.TempDir()
+	c, err := newResponseCache(dir, 0)
+	if err != nil {
+		t.Fatalf("newResponseCache: %v", err)
+	}
+
+	entry := cacheEntry{
+		URL:          "https://example.com/",
+		ETag:         `"abc123"`,
+		LastModified: "Wed, 21 Oct 2015 07:28:00 GMT",
+		Body:         []byte("hello world"),
+		StatusCode:   200,
+	}
+	if err := c.put(entry); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	got, ok := c.get(entry.URL)
+	if !ok {
+		t.Fatal("get: expected cache hit")
+	}
+	if got.URL != entry.URL || got.ETag != entry.ETag || got.LastModified != entry.LastModified ||
+		string(got.Body) != string(entry.Body) || got.StatusCode != entry.StatusCode {
+		t.Errorf("get() = %+v, want %+v", got, entry)
+	}
+	if got.StoredAt.IsZero() {
+		t.Error("expected put to stamp StoredAt")
+	}
+}
+
+func TestResponseCacheGetMiss(t *testing.T) {
+	dir := t.TempDir()
+	c, err := newResponseCache(dir, 0)
+	if err != nil {
+		t.Fatalf("newResponseCache: %v", err)
+	}
+	if _, ok := c.get("https://example.com/never-cached"); ok {
+		t.Error("expected cache miss for a URL that was never stored")
+	}
+}
+
+func TestResponseCacheTTLExpiry(t *testing.T) {
+	dir := t.TempDir()
+	c, err := newResponseCache(dir, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("newResponseCache: %v", err)
+	}
+
+	entry := cacheEntry{URL: "https://example.com/", Body: []byte("stale soon"), StatusCode: 200}
+	if err := c.put(entry); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	if _, ok := c.get(entry.URL); !ok {
+		t.Fatal("expected cache hit before TTL elapses")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := c.get(entry.URL); ok {
+		t.Error("expected cache miss after TTL elapses")
+	}
+}
+
+func TestResponseCacheKeyForIsStablePerURL(t *testing.T) {
+	dir := t.TempDir()
+	c, err := newResponseCache(dir, 0)
+	if err != nil {
+		t.Fatalf("newResponseCache: %v", err)
+	}
+	a := c.keyFor("https://example.com/a")
+	b := c.keyFor("https://example.com/b")
+	if a == b {
+		t.Error("expected different URLs to produce different cache keys")
+	}
+	if c.keyFor("https://example.com/a") != a {
+		t.Error("expected keyFor to be stable for the same URL")
+	}
+}
+
+func TestResponseCachePurge(t *testing.T) {
+	dir := t.TempDir()
+	c, err := newResponseCache(dir, 0)
+	if err != nil {
+		t.Fatalf("newResponseCache: %v", err)
+	}
+	for _, u := range []string{"https://example.com/a", "https://example.com/b"} {
+		if err := c.put(cacheEntry{URL: u, Body: []byte("x"), StatusCode: 200}); err != nil {
+			t.Fatalf("put(%q): %v", u, err)
+		}
+	}
+
+	n, err := c.purge()
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("purge() = %d, want 2", n)
+	}
+	if _, ok := c.get("https://example.com/a"); ok {
+		t.Error("expected purge to remove all cache entries")
+	}
+}
+
+func TestApplyConditionalHeaders(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := newResponseCache(dir, 0)
+	if err != nil {
+		t.Fatalf("newResponseCache: %v", err)
+	}
+	if err := cache.put(cacheEntry{
+		URL:          "https://example.com/",
+		ETag:         `"abc123"`,
+		LastModified: "Wed, 21 Oct 2015 07:28:00 GMT",
+		Body:         []byte("hello"),
+		StatusCode:   200,
+	}); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	c := &URLChecker{cache: cache}
+	req, err := http.NewRequest(http.MethodGet, "https://example.com/", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+	c.applyConditionalHeaders(req, "https://example.com/")
+
+	if got := req.Header.Get("If-None-Match"); got != `"abc123"` {
+		t.Errorf("If-None-Match = %q, want %q", got, `"abc123"`)
+	}
+	if got := req.Header.Get("If-Modified-Since"); got != "Wed, 21 Oct 2015 07:28:00 GMT" {
+		t.Errorf("If-Modified-Since = %q, want %q", got, "Wed, 21 Oct 2015 07:28:00 GMT")
+	}
+}
+
+func TestApplyConditionalHeadersNoCacheEntry(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := newResponseCache(dir, 0)
+	if err != nil {
+		t.Fatalf("newResponseCache: %v", err)
+	}
+
+	c := &URLChecker{cache: cache}
+	req, err := http.NewRequest(http.MethodGet, "https://example.com/", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+	c.applyConditionalHeaders(req, "https://example.com/")
+
+	if req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		t.Error("expected no conditional headers without a cache entry")
+	}
+}
+
+func TestApplyConditionalHeadersNilCache(t *testing.T) {
+	c := &URLChecker{}
+	req, err := http.NewRequest(http.MethodGet, "https://example.com/", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+	c.applyConditionalHeaders(req, "https://example.com/")
+
+	if req.Header.Get("If-None-Match") != "" {
+		t.Error("expected applyConditionalHeaders to be a no-op with a nil cache")
+	}
+}
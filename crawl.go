@@ -0,0 +1,252 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// crawlEdge records which page linked to which discovered URL, so crawl
+// results can optionally be emitted as an edge-list alongside CSV/JSON.
+type crawlEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// crawlOptions controls the scope of a crawl.
+type crawlOptions struct {
+	depth         int
+	includeSubs   bool
+	concurrency   int
+	delay         time.Duration
+	respectRobots bool
+	useSitemap    bool
+	keyword       string
+}
+
+// hrefPattern is a lightweight HTML attribute scanner covering the tags the
+// request asked for (<a href>, <script src>, <link href>, <form action>).
+// A full HTML parser is overkill for link discovery on arbitrary pages.
+var hrefPattern = regexp.MustCompile(`(?is)<(?:a|link|form)\s[^>]*?(?:href|action)\s*=\s*["']([^"']+)["']|<script\s[^>]*?src\s*=\s*["']([^"']+)["']`)
+
+// extractLinks finds candidate URLs in an HTML document and resolves them
+// against base.
+func extractLinks(base *url.URL, body []byte) []string {
+	var links []string
+	for _, m := range hrefPattern.FindAllSubmatch(body, -1) {
+		raw := string(m[1])
+		if raw == "" {
+			raw = string(m[2])
+		}
+		raw = strings.TrimSpace(raw)
+		if raw == "" || strings.HasPrefix(raw, "#") || strings.HasPrefix(raw, "javascript:") || strings.HasPrefix(raw, "mailto:") {
+			continue
+		}
+		resolved, err := base.Parse(raw)
+		if err != nil {
+			continue
+		}
+		resolved.Fragment = ""
+		links = append(links, resolved.String())
+	}
+	return links
+}
+
+// inScope reports whether candidate is within the crawl scope of seed,
+// honoring --include-subs (main domain only vs. subdomains).
+func inScope(seed, candidate *url.URL, includeSubs bool) bool {
+	if candidate.Scheme != "http" && candidate.Scheme != "https" {
+		return false
+	}
+	if candidate.Hostname() == seed.Hostname() {
+		return true
+	}
+	if includeSubs && strings.HasSuffix(candidate.Hostname(), "."+registrableSuffix(seed.Hostname())) {
+		return true
+	}
+	return false
+}
+
+// registrableSuffix returns the last two labels of a hostname as a rough
+// stand-in for the registrable domain (e.g. "example.com" from
+// "www.example.com"). Good enough for --include-subs scoping without
+// pulling in a public-suffix-list dependency.
+func registrableSuffix(host string) string {
+	parts := strings.Split(host, ".")
+	if len(parts) < 2 {
+		return host
+	}
+	return strings.Join(parts[len(parts)-2:], ".")
+}
+
+// fetchRobotsDisallow fetches robots.txt for seed's host and returns the
+// disallowed path prefixes for the wildcard user agent. Errors are treated
+// as "no restrictions" rather than aborting the crawl.
+func fetchRobotsDisallow(client *http.Client, seed *url.URL) []string {
+	robotsURL := fmt.Sprintf("%s://%s/robots.txt", seed.Scheme, seed.Host)
+	resp, err := client.Get(robotsURL)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil
+	}
+
+	var disallows []string
+	applies := false
+	for _, line := range strings.Split(string(body), "\n") {
+		line = strings.TrimSpace(line)
+		lower := strings.ToLower(line)
+		switch {
+		case strings.HasPrefix(lower, "user-agent:"):
+			agent := strings.TrimSpace(line[len("User-Agent:"):])
+			applies = agent == "*"
+		case applies && strings.HasPrefix(lower, "disallow:"):
+			path := strings.TrimSpace(line[len("Disallow:"):])
+			if path != "" {
+				disallows = append(disallows, path)
+			}
+		}
+	}
+	return disallows
+}
+
+// robotsAllowed reports whether path is permitted given a set of disallowed
+// prefixes.
+func robotsAllowed(disallows []string, path string) bool {
+	for _, d := range disallows {
+		if strings.HasPrefix(path, d) {
+			return false
+		}
+	}
+	return true
+}
+
+// sitemapLocPattern extracts <loc> entries from a sitemap.xml (or a plain
+// sitemap index). A lightweight regex scan matches the approach already
+// used for HTML link discovery rather than pulling in a full XML parser.
+var sitemapLocPattern = regexp.MustCompile(`(?is)<loc>\s*([^<\s]+)\s*</loc>`)
+
+// fetchSitemapURLs fetches sitemap.xml for seed's host and returns the URLs
+// it lists. A missing or unreachable sitemap yields no URLs rather than
+// aborting the crawl, matching fetchRobotsDisallow's fail-open behavior.
+func fetchSitemapURLs(client *http.Client, seed *url.URL) []string {
+	sitemapURL := fmt.Sprintf("%s://%s/sitemap.xml", seed.Scheme, seed.Host)
+	resp, err := client.Get(sitemapURL)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil
+	}
+
+	var urls []string
+	for _, m := range sitemapLocPattern.FindAllSubmatch(body, -1) {
+		urls = append(urls, strings.TrimSpace(string(m[1])))
+	}
+	return urls
+}
+
+// crawl performs a breadth-first crawl starting at seedURL, checking every
+// discovered in-scope URL with the existing concurrent checker pipeline and
+// returning the results plus the page->link edges observed.
+func (c *URLChecker) crawl(seedURL string, opts crawlOptions) ([]URLResult, []crawlEdge, error) {
+	seed, err := url.Parse(seedURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid seed URL: %v", err)
+	}
+
+	var disallows []string
+	if opts.respectRobots {
+		disallows = fetchRobotsDisallow(c.client, seed)
+	}
+
+	if opts.concurrency > 0 {
+		c.concurrency = opts.concurrency
+	}
+	// crawl always needs the page body to discover links, regardless of
+	// --fast/--method HEAD.
+	c.forceBody = true
+
+	visited := map[string]bool{seedURL: true}
+	type queued struct {
+		url   string
+		depth int
+	}
+	queue := []queued{{url: seedURL, depth: 0}}
+
+	if opts.useSitemap {
+		for _, link := range fetchSitemapURLs(c.client, seed) {
+			parsed, err := url.Parse(link)
+			if err != nil || !inScope(seed, parsed, opts.includeSubs) {
+				continue
+			}
+			if opts.respectRobots && !robotsAllowed(disallows, parsed.Path) {
+				continue
+			}
+			if !visited[link] {
+				visited[link] = true
+				queue = append(queue, queued{url: link, depth: 0})
+			}
+		}
+	}
+
+	var results []URLResult
+	var edges []crawlEdge
+
+	for len(queue) > 0 {
+		batch := queue
+		queue = nil
+
+		var urls []string
+		for _, q := range batch {
+			urls = append(urls, q.url)
+		}
+		batchResults := c.checkURLs(urls, opts.keyword)
+		results = append(results, batchResults...)
+
+		for i, q := range batch {
+			if opts.delay > 0 {
+				time.Sleep(opts.delay)
+			}
+			if q.depth >= opts.depth {
+				continue
+			}
+			if batchResults[i].Error != "" || len(batchResults[i].body) == 0 {
+				continue
+			}
+
+			pageURL, _ := url.Parse(q.url)
+			for _, link := range extractLinks(pageURL, batchResults[i].body) {
+				parsed, err := url.Parse(link)
+				if err != nil || !inScope(seed, parsed, opts.includeSubs) {
+					continue
+				}
+				if opts.respectRobots && !robotsAllowed(disallows, parsed.Path) {
+					continue
+				}
+				edges = append(edges, crawlEdge{From: q.url, To: link})
+				if !visited[link] {
+					visited[link] = true
+					queue = append(queue, queued{url: link, depth: q.depth + 1})
+				}
+			}
+		}
+	}
+
+	return results, edges, nil
+}
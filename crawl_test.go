@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExtractLinks(t *testing.T) {
+	base, err := url.Parse("https://example.com/dir/page.html")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	body := []byte(`
+		<a href="/about">About</a>
+		<a href="other.html#section">Other</a>
+		<link href="style.css">
+		<script src="app.js"></script>
+		<form action="/submit"></form>
+		<a href="javascript:void(0)">noop</a>
+		<a href="mailto:foo@example.com">mail</a>
+		<a href="#top">anchor only</a>
+	`)
+
+	got := extractLinks(base, body)
+	sort.Strings(got)
+
+	want := []string{
+		"https://example.com/about",
+		"https://example.com/dir/app.js",
+		"https://example.com/dir/other.html",
+		"https://example.com/dir/style.css",
+		"https://example.com/submit",
+	}
+	sort.Strings(want)
+
+	if len(got) != len(want) {
+		t.Fatalf("extractLinks() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("extractLinks()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestInScope(t *testing.T) {
+	seed, _ := url.Parse("https://example.com/")
+
+	tests := []struct {
+		name        string
+		candidate   string
+		includeSubs bool
+		want        bool
+	}{
+		{"same host", "https://example.com/page", false, true},
+		{"subdomain excluded by default", "https://blog.example.com/page", false, false},
+		{"subdomain included with includeSubs", "https://blog.example.com/page", true, true},
+		{"different domain excluded", "https://other.com/page", true, false},
+		{"non-http scheme excluded", "mailto:foo@example.com", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidate, err := url.Parse(tt.candidate)
+			if err != nil {
+				t.Fatalf("url.Parse(%q): %v", tt.candidate, err)
+			}
+			if got := inScope(seed, candidate, tt.includeSubs); got != tt.want {
+				t.Errorf("inScope(%q) = %v, want %v", tt.candidate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistrableSuffix(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"www.example.com", "example.com"},
+		{"example.com", "example.com"},
+		{"a.b.example.com", "example.com"},
+		{"localhost", "localhost"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if got := registrableSuffix(tt.host); got != tt.want {
+				t.Errorf("registrableSuffix(%q) = %q, want %q", tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRobotsAllowed(t *testing.T) {
+	disallows := []string{"/admin", "/private"}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/", true},
+		{"/about", true},
+		{"/admin", false},
+		{"/admin/settings", false},
+		{"/private/data", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := robotsAllowed(disallows, tt.path); got != tt.want {
+				t.Errorf("robotsAllowed(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRobotsAllowedNoDisallows(t *testing.T) {
+	if !robotsAllowed(nil, "/anything") {
+		t.Error("expected robotsAllowed to permit everything when there are no disallow rules")
+	}
+}
+
+// TestCrawlHonorsConcurrency guards against crawlOptions.concurrency being a
+// no-op: the linked pages below are all fetched in one BFS batch, so without
+// a real bound every request would fire at once.
+func TestCrawlHonorsConcurrency(t *testing.T) {
+	const linkCount = 6
+	const limit = 2
+
+	var inFlight, maxInFlight int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		var links string
+		for i := 0; i < linkCount; i++ {
+			links += fmt.Sprintf(`<a href="/p%d">p%d</a>`, i, i)
+		}
+		fmt.Fprintf(w, "<html><body>%s</body></html>", links)
+	})
+	for i := 0; i < linkCount; i++ {
+		mux.HandleFunc(fmt.Sprintf("/p%d", i), func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			fmt.Fprint(w, "ok")
+		})
+	}
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := &URLChecker{client: srv.Client()}
+	_, _, err := c.crawl(srv.URL+"/", crawlOptions{depth: 1, concurrency: limit})
+	if err != nil {
+		t.Fatalf("crawl: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&maxInFlight); got > limit {
+		t.Errorf("max concurrent in-flight requests = %d, want <= %d", got, limit)
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"net/url"
 	"os"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -17,17 +16,45 @@ import (
 
 // URLResult represents the check result for a single URL
 type URLResult struct {
-	URL          string        `json:"url"`
-	StatusCode   int           `json:"status_code"`
-	ResponseTime time.Duration `json:"response_time"`
-	KeywordFound bool          `json:"keyword_found"`
-	Error        string        `json:"error"`
+	URL          string             `json:"url"`
+	StatusCode   int                `json:"status_code"`
+	ResponseTime time.Duration      `json:"response_time"`
+	KeywordFound bool               `json:"keyword_found"`
+	Error        string             `json:"error"`
+	Attempts     []AttemptRecord    `json:"attempts,omitempty"`
+	CacheHit     bool               `json:"cache_hit,omitempty"`
+	Validations  []ValidationResult `json:"validations,omitempty"`
+
+	// body is the last response body read for this URL, if any. It is not
+	// part of the public result (CSV/JSON output), but lets callers that
+	// already paid for one fetch (e.g. crawl's link extraction) reuse it
+	// instead of fetching the page again.
+	body []byte
 }
 
 // URLChecker manages URL checking operations
 type URLChecker struct {
-	client  *http.Client
-	retries int
+	client        *http.Client
+	retries       int
+	backoff       backoffMode
+	backoffBase   time.Duration
+	backoffJitter bool
+	retryOn       retryOnSet
+	expectRegex   string
+	cache         *responseCache
+	method        string
+	headers       []string
+	userAgent     string
+	fast          bool
+	validators    []Validator
+	concurrency   int
+	rps           float64
+	perHostDelay  time.Duration
+	maxPerHost    int
+
+	// forceBody overrides --fast/HEAD for callers (crawl) that always need
+	// the response body regardless of keyword/expect-regex/validators.
+	forceBody bool
 }
 
 // NewURLChecker initializes a URLChecker with a timeout and retry count
@@ -36,7 +63,11 @@ func NewURLChecker(timeout time.Duration, retries int) *URLChecker {
 		client: &http.Client{
 			Timeout: timeout,
 		},
-		retries: retries,
+		retries:     retries,
+		backoff:     backoffFixed,
+		backoffBase: 500 * time.Millisecond,
+		retryOn:     retryOnSet{retryOnTransport: true, retryOnServerError: true},
+		method:      http.MethodGet,
 	}
 }
 
@@ -68,64 +99,165 @@ func (c *URLChecker) checkURL(rawURL, keyword string) URLResult {
 		return result
 	}
 
-	var resp *http.Response
-	var err error
 	start := time.Now()
+	var body []byte
+	var err error
 
-	// Attempt request with retries
+	// Attempt request with retries, firing again not just on transport
+	// errors but also when the parsed content fails validation (a 5xx, or
+	// a body that doesn't match --keyword/--expect-regex).
+	needsBody := keyword != "" || c.expectRegex != "" || validatorsNeedBody(c.validators) || c.forceBody
 	for attempt := 0; attempt <= c.retries; attempt++ {
-		resp, err = c.client.Get(rawURL)
-		if err == nil {
+		attemptStart := time.Now()
+		record := AttemptRecord{Attempt: attempt + 1}
+
+		method := c.method
+		if c.fast && method == http.MethodGet && !needsBody {
+			method = http.MethodHead
+		}
+
+		req, reqErr := c.newRequest(rawURL, method)
+		if reqErr != nil {
+			result.Error = reqErr.Error()
+			return result
+		}
+
+		resp, reqErr := c.client.Do(req)
+		if reqErr != nil {
+			record.Error = reqErr.Error()
+			record.ResponseTime = time.Since(attemptStart)
+			result.Attempts = append(result.Attempts, record)
+			err = reqErr
+			if attempt < c.retries && c.retryOn.allows(retryOnTransport) {
+				time.Sleep(retryDelay(c.backoff, c.backoffBase, attempt, c.backoffJitter))
+				continue
+			}
 			break
 		}
-		if attempt < c.retries {
-			time.Sleep(time.Duration(attempt+1) * 500 * time.Millisecond)
+
+		// A server that refuses HEAD (405/501) needs the GET fallback to
+		// get a real status; this isn't a fresh retry attempt.
+		if method == http.MethodHead && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented) {
+			resp.Body.Close()
+			fallbackReq, reqErr2 := c.newRequest(rawURL, http.MethodGet)
+			if reqErr2 != nil {
+				result.Error = reqErr2.Error()
+				return result
+			}
+			resp, reqErr = c.client.Do(fallbackReq)
+			if reqErr != nil {
+				record.Error = reqErr.Error()
+				record.ResponseTime = time.Since(attemptStart)
+				result.Attempts = append(result.Attempts, record)
+				err = reqErr
+				if attempt < c.retries && c.retryOn.allows(retryOnTransport) {
+					time.Sleep(retryDelay(c.backoff, c.backoffBase, attempt, c.backoffJitter))
+					continue
+				}
+				break
+			}
+			method = http.MethodGet
 		}
-	}
 
-	if err != nil {
-		result.Error = fmt.Sprintf("failed after %d retries: %v", c.retries+1, err)
-		return result
-	}
-	defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNotModified && c.cache != nil {
+			if entry, ok := c.cache.get(rawURL); ok {
+				record.StatusCode = entry.StatusCode
+				record.ResponseTime = time.Since(attemptStart)
+				result.Attempts = append(result.Attempts, record)
+				body = entry.Body
+				result.StatusCode = entry.StatusCode
+				result.CacheHit = true
+				// A cache hit still has to pass the same content/validator
+				// checks a fresh fetch would, or --expect-* flags would be
+				// silently skipped on every repeated batch run. Validate
+				// against the cached entry's own status/headers, not the
+				// 304 response's, so --expect-status/--expect-header see
+				// what was actually cached rather than the conditional
+				// response.
+				if len(c.validators) > 0 {
+					cachedResp := &http.Response{
+						StatusCode: entry.StatusCode,
+						Header:     http.Header{},
+					}
+					if entry.ETag != "" {
+						cachedResp.Header.Set("ETag", entry.ETag)
+					}
+					if entry.LastModified != "" {
+						cachedResp.Header.Set("Last-Modified", entry.LastModified)
+					}
+					result.Validations = runValidators(c.validators, cachedResp, body)
+				}
+				resp.Body.Close()
+				err = nil
+				break
+			}
+			resp.Body.Close()
+		}
 
-	result.StatusCode = resp.StatusCode
-	result.ResponseTime = time.Since(start)
+		b, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		record.StatusCode = resp.StatusCode
+		record.ResponseTime = time.Since(attemptStart)
 
-	if keyword != "" {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			result.Error = fmt.Sprintf("error reading body: %v", err)
-			return result
+		if readErr != nil {
+			record.Error = readErr.Error()
+			result.Attempts = append(result.Attempts, record)
+			err = readErr
+			break
 		}
-		result.KeywordFound = strings.Contains(strings.ToLower(string(body)), strings.ToLower(keyword))
-	}
 
-	return result
-}
+		// A HEAD response has no body to cache; writing it here would
+		// overwrite a previously-cached full body with an empty one, which
+		// a later run needing the body (e.g. --keyword) would then read
+		// back on a 304 cache hit.
+		if c.cache != nil && resp.StatusCode == http.StatusOK && method != http.MethodHead {
+			c.cache.put(cacheEntry{
+				URL:          rawURL,
+				ETag:         resp.Header.Get("ETag"),
+				LastModified: resp.Header.Get("Last-Modified"),
+				Body:         b,
+				StatusCode:   resp.StatusCode,
+			})
+		}
+
+		if validErr := validateContent(b, resp.StatusCode, c.expectRegex); validErr != nil {
+			record.Error = validErr.Error()
+			result.Attempts = append(result.Attempts, record)
+			err = validErr
+			retryable := (resp.StatusCode >= 500 && c.retryOn.allows(retryOnServerError)) ||
+				(resp.StatusCode < 500 && c.retryOn.allows(retryOnInvalidBody))
+			if attempt < c.retries && retryable {
+				time.Sleep(retryDelay(c.backoff, c.backoffBase, attempt, c.backoffJitter))
+				continue
+			}
+			body = b
+			result.StatusCode = resp.StatusCode
+			break
+		}
 
-// checkURLs checks multiple URLs concurrently
-func (c *URLChecker) checkURLs(urls []string, keyword string) []URLResult {
-	var wg sync.WaitGroup
-	results := make([]URLResult, len(urls))
-	resultChan := make(chan URLResult, len(urls))
-
-	for i, url := range urls {
-		wg.Add(1)
-		go func(idx int, u string) {
-			defer wg.Done()
-			resultChan <- c.checkURL(u, keyword)
-		}(i, url)
+		result.Attempts = append(result.Attempts, record)
+		body = b
+		result.StatusCode = resp.StatusCode
+		if len(c.validators) > 0 {
+			result.Validations = runValidators(c.validators, resp, b)
+		}
+		err = nil
+		break
 	}
 
-	wg.Wait()
-	close(resultChan)
+	result.ResponseTime = time.Since(start)
+	result.body = body
+
+	if err != nil && result.StatusCode == 0 {
+		result.Error = fmt.Sprintf("failed after %d attempts: %v", len(result.Attempts), err)
+		return result
+	}
 
-	for i := 0; i < len(urls); i++ {
-		results[i] = <-resultChan
+	if keyword != "" {
+		result.KeywordFound = strings.Contains(strings.ToLower(string(body)), strings.ToLower(keyword))
 	}
 
-	return results
+	return result
 }
 
 // writeCSV writes results to a CSV file
@@ -140,7 +272,7 @@ func writeCSV(results []URLResult, outputFile string) error {
 	defer writer.Flush()
 
 	// Write header
-	if err := writer.Write([]string{"URL", "StatusCode", "ResponseTime", "KeywordFound", "Error"}); err != nil {
+	if err := writer.Write([]string{"URL", "StatusCode", "ResponseTime", "KeywordFound", "Error", "Attempts", "Validations"}); err != nil {
 		return err
 	}
 
@@ -152,6 +284,8 @@ func writeCSV(results []URLResult, outputFile string) error {
 			r.ResponseTime.String(),
 			fmt.Sprintf("%t", r.KeywordFound),
 			r.Error,
+			attemptsSummary(r.Attempts),
+			validationsSummary(r.Validations),
 		}); err != nil {
 			return err
 		}
@@ -173,6 +307,25 @@ func main() {
 	var timeoutSeconds int
 	var retries int
 	var outputFormat string
+	var retryBackoff string
+	var retryOn string
+	var retryJitter bool
+	var expectRegex string
+	var cacheDir string
+	var cacheTTL time.Duration
+	var method string
+	var fast bool
+	var headers []string
+	var userAgent string
+	var expectStatus string
+	var expectJSON []string
+	var expectXPath []string
+	var expectHeader []string
+	var expectSHA256 string
+	var concurrency int
+	var rps float64
+	var perHostDelay time.Duration
+	var maxPerHost int
 
 	// Root command
 	var rootCmd = &cobra.Command{
@@ -196,14 +349,118 @@ func main() {
 	rootCmd.PersistentFlags().IntVar(&timeoutSeconds, "timeout", 10, "HTTP request timeout in seconds")
 	rootCmd.PersistentFlags().IntVar(&retries, "retries", 0, "Number of retries for failed requests")
 	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", "csv", "Output format: csv or json")
+	rootCmd.PersistentFlags().StringVar(&retryBackoff, "retry-backoff", "fixed", "Retry backoff mode: fixed, linear, or exponential")
+	rootCmd.PersistentFlags().StringVar(&retryOn, "retry-on", "5xx,timeout", "Comma-separated conditions to retry on: 5xx, timeout, invalid-body")
+	rootCmd.PersistentFlags().BoolVar(&retryJitter, "retry-jitter", false, "Add random jitter to retry backoff delays")
+	rootCmd.PersistentFlags().StringVar(&expectRegex, "expect-regex", "", "Regex the response body must match; a mismatch is treated as a failed attempt")
+	rootCmd.PersistentFlags().StringVar(&cacheDir, "cache-dir", "", "Directory to cache response bodies for conditional GETs (disabled if empty)")
+	rootCmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", 0, "Expire cache entries older than this duration (0 disables time-based expiry)")
+	rootCmd.PersistentFlags().StringVar(&method, "method", "GET", "HTTP method to use: GET, HEAD, or POST")
+	rootCmd.PersistentFlags().BoolVar(&fast, "fast", false, "Issue HEAD first and only fall back to GET when required")
+	rootCmd.PersistentFlags().StringArrayVar(&headers, "header", nil, "Extra request header \"Key: Value\" (repeatable)")
+	rootCmd.PersistentFlags().StringVar(&userAgent, "user-agent", "", "User-Agent header to send with requests")
+	rootCmd.PersistentFlags().StringVar(&expectStatus, "expect-status", "", "Comma-separated accepted status codes/classes, e.g. \"2xx,301\"")
+	rootCmd.PersistentFlags().StringArrayVar(&expectJSON, "expect-json", nil, "Assert a JSONPath equals a value: \"<jsonpath>=<value>\" (repeatable)")
+	rootCmd.PersistentFlags().StringArrayVar(&expectXPath, "expect-xpath", nil, "Assert an XPath expression matches the HTML body (repeatable)")
+	rootCmd.PersistentFlags().StringArrayVar(&expectHeader, "expect-header", nil, "Assert a response header equals a value: \"Key: Value\" (repeatable)")
+	rootCmd.PersistentFlags().StringVar(&expectSHA256, "expect-sha256", "", "Assert the response body's sha256 hash matches this hex digest")
+	rootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", 20, "Maximum number of URLs checked at once")
+	rootCmd.PersistentFlags().Float64Var(&rps, "rps", 0, "Global requests-per-second cap across all URLs (0 disables rate limiting)")
+	rootCmd.PersistentFlags().DurationVar(&perHostDelay, "per-host-delay", 0, "Minimum delay between consecutive requests to the same host")
+	rootCmd.PersistentFlags().IntVar(&maxPerHost, "max-per-host", 0, "Maximum concurrent requests to the same host (0 defaults to --concurrency)")
 
 	// Initialize checker after flags are parsed
 	var checker *URLChecker
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		mode, err := parseBackoffMode(retryBackoff)
+		if err != nil {
+			return err
+		}
 		checker = NewURLChecker(time.Duration(timeoutSeconds)*time.Second, retries)
+		checker.backoff = mode
+		checker.backoffJitter = retryJitter
+		checker.retryOn = parseRetryOn(retryOn)
+		checker.expectRegex = expectRegex
+		upperMethod := strings.ToUpper(method)
+		if upperMethod != http.MethodGet && upperMethod != http.MethodHead && upperMethod != http.MethodPost {
+			return fmt.Errorf("invalid method %q (want GET, HEAD, or POST)", method)
+		}
+		checker.method = upperMethod
+		checker.fast = fast
+		checker.headers = headers
+		checker.userAgent = userAgent
+		checker.concurrency = concurrency
+		checker.rps = rps
+		checker.perHostDelay = perHostDelay
+		checker.maxPerHost = maxPerHost
+
+		if expectStatus != "" {
+			checker.validators = append(checker.validators, statusClassValidator{spec: expectStatus})
+		}
+		if expectRegex != "" {
+			rv, err := newRegexValidator(expectRegex)
+			if err != nil {
+				return err
+			}
+			checker.validators = append(checker.validators, rv)
+		}
+		for _, spec := range expectJSON {
+			jv, err := newJSONPathValidator(spec)
+			if err != nil {
+				return err
+			}
+			checker.validators = append(checker.validators, jv)
+		}
+		for _, expr := range expectXPath {
+			checker.validators = append(checker.validators, xpathValidator{expr: expr})
+		}
+		for _, spec := range expectHeader {
+			hv, err := newHeaderValidator(spec)
+			if err != nil {
+				return err
+			}
+			checker.validators = append(checker.validators, hv)
+		}
+		if expectSHA256 != "" {
+			checker.validators = append(checker.validators, sha256Validator{want: expectSHA256})
+		}
+
+		if cacheDir != "" {
+			cache, err := newResponseCache(cacheDir, cacheTTL)
+			if err != nil {
+				return fmt.Errorf("initializing cache: %v", err)
+			}
+			checker.cache = cache
+		}
 		return nil
 	}
 
+	// Cache command group
+	var cacheCmd = &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the on-disk response cache",
+	}
+	var cachePurgeCmd = &cobra.Command{
+		Use:   "purge",
+		Short: "Evict all entries from the response cache",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cacheDir == "" {
+				return fmt.Errorf("--cache-dir must be set")
+			}
+			cache, err := newResponseCache(cacheDir, cacheTTL)
+			if err != nil {
+				return err
+			}
+			n, err := cache.purge()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Purged %d cache entries from %s\n", n, cacheDir)
+			return nil
+		},
+	}
+	cacheCmd.AddCommand(cachePurgeCmd)
+
 	// Check command
 	var keyword string
 	var checkCmd = &cobra.Command{
@@ -213,6 +470,9 @@ func main() {
 		Run: func(cmd *cobra.Command, args []string) {
 			result := checker.checkURL(args[0], keyword)
 			printResult(result)
+			if result.Error != "" || !allPassed(result.Validations) {
+				os.Exit(1)
+			}
 		},
 	}
 	checkCmd.Flags().StringVarP(&keyword, "keyword", "k", "", "Keyword to search for in the page")
@@ -224,12 +484,22 @@ func main() {
 		Short: "Check multiple URLs from a file",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			urls, err := readURLs(args[0])
-			if err != nil {
-				fmt.Println("Error reading URLs:", err)
-				return
+			var results []URLResult
+			if isExtendedTargetsFile(args[0]) {
+				targets, err := readTargets(args[0])
+				if err != nil {
+					fmt.Println("Error reading targets:", err)
+					return
+				}
+				results = checker.checkTargets(targets)
+			} else {
+				urls, err := readURLs(args[0])
+				if err != nil {
+					fmt.Println("Error reading URLs:", err)
+					return
+				}
+				results = checker.checkURLs(urls, keyword)
 			}
-			results := checker.checkURLs(urls, keyword)
 			for _, result := range results {
 				printResult(result)
 			}
@@ -246,13 +516,129 @@ func main() {
 				}
 				fmt.Println("Results saved to", outputFile)
 			}
+			for _, result := range results {
+				if result.Error != "" || !allPassed(result.Validations) {
+					os.Exit(1)
+				}
+			}
 		},
 	}
 	listCmd.Flags().StringVarP(&keyword, "keyword", "k", "", "Keyword to search for in pages")
 	listCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file for results (CSV or JSON based on --format)")
 
+	// Crawl command
+	var crawlDepth int
+	var crawlIncludeSubs bool
+	var crawlConcurrency int
+	var crawlDelay time.Duration
+	var crawlRespectRobots bool
+	var crawlUseSitemap bool
+	var crawlEdgesFile string
+	var crawlCmd = &cobra.Command{
+		Use:   "crawl [url]",
+		Short: "Recursively crawl a site and check discovered URLs",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			results, edges, err := checker.crawl(args[0], crawlOptions{
+				depth:         crawlDepth,
+				includeSubs:   crawlIncludeSubs,
+				concurrency:   crawlConcurrency,
+				delay:         crawlDelay,
+				respectRobots: crawlRespectRobots,
+				useSitemap:    crawlUseSitemap,
+				keyword:       keyword,
+			})
+			if err != nil {
+				return err
+			}
+			for _, result := range results {
+				printResult(result)
+			}
+			if outputFile != "" {
+				if outputFormat == "csv" {
+					err = writeCSV(results, outputFile)
+				} else {
+					err = writeJSON(results, outputFile)
+				}
+				if err != nil {
+					return fmt.Errorf("writing output: %v", err)
+				}
+				fmt.Println("Results saved to", outputFile)
+			}
+			if crawlEdgesFile != "" {
+				data, err := json.MarshalIndent(edges, "", "  ")
+				if err != nil {
+					return err
+				}
+				if err := os.WriteFile(crawlEdgesFile, data, 0644); err != nil {
+					return err
+				}
+				fmt.Println("Edge list saved to", crawlEdgesFile)
+			}
+			for _, result := range results {
+				if result.Error != "" || !allPassed(result.Validations) {
+					os.Exit(1)
+				}
+			}
+			return nil
+		},
+	}
+	crawlCmd.Flags().IntVar(&crawlDepth, "depth", 1, "Maximum crawl depth from the seed URL")
+	crawlCmd.Flags().BoolVar(&crawlIncludeSubs, "include-subs", false, "Include subdomains of the seed host in scope (default: main domain only)")
+	crawlCmd.Flags().IntVar(&crawlConcurrency, "concurrency", 10, "Maximum concurrent requests")
+	crawlCmd.Flags().DurationVar(&crawlDelay, "delay", 0, "Delay between fetching successive batches of URLs")
+	crawlCmd.Flags().BoolVar(&crawlRespectRobots, "respect-robots", false, "Honor robots.txt disallow rules for the seed host")
+	crawlCmd.Flags().BoolVar(&crawlUseSitemap, "use-sitemap", false, "Seed the crawl with URLs discovered in sitemap.xml")
+	crawlCmd.Flags().StringVarP(&keyword, "keyword", "k", "", "Keyword to search for in pages")
+	crawlCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file for results (CSV or JSON based on --format)")
+	crawlCmd.Flags().StringVar(&crawlEdgesFile, "edges-output", "", "Write a JSON edge-list of page->link relationships to this file")
+
+	// Monitor command
+	var monitorInterval time.Duration
+	var monitorMetricsAddr string
+	var monitorCmd = &cobra.Command{
+		Use:   "monitor [file]",
+		Short: "Repeatedly check URLs on an interval and expose Prometheus metrics",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			urls, err := readURLs(args[0])
+			if err != nil {
+				return fmt.Errorf("reading URLs: %v", err)
+			}
+
+			metrics := newURLMetrics()
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", metrics.handler())
+			server := &http.Server{Addr: monitorMetricsAddr, Handler: mux}
+			go func() {
+				if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					fmt.Println("Metrics server error:", err)
+				}
+			}()
+			fmt.Println("Serving metrics on", monitorMetricsAddr+"/metrics")
+
+			runOnce := func() {
+				for _, result := range checker.checkURLs(urls, keyword) {
+					printResult(result)
+					metrics.record(result)
+				}
+			}
+
+			runOnce()
+			ticker := time.NewTicker(monitorInterval)
+			defer ticker.Stop()
+			for range ticker.C {
+				runOnce()
+			}
+			return nil
+		},
+	}
+	monitorCmd.Flags().StringVarP(&keyword, "keyword", "k", "", "Keyword to search for in pages")
+	monitorCmd.Flags().DurationVar(&monitorInterval, "interval", time.Minute, "How often to re-check the URL list")
+	monitorCmd.Flags().StringVar(&monitorMetricsAddr, "metrics-addr", ":9090", "Address to serve Prometheus metrics on")
+
 	// Add commands to root
-	rootCmd.AddCommand(checkCmd, listCmd)
+	rootCmd.AddCommand(checkCmd, listCmd, cacheCmd, crawlCmd, monitorCmd)
 
 	// Execute
 	if err := rootCmd.Execute(); err != nil {
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// urlMetrics holds the latest check outcome per URL for the monitor
+// subcommand, rendered as Prometheus text format on scrape.
+type urlMetrics struct {
+	mu      sync.RWMutex
+	results map[string]URLResult
+	retries map[string]int
+	errors  map[string]int
+}
+
+// newURLMetrics creates an empty metrics registry.
+func newURLMetrics() *urlMetrics {
+	return &urlMetrics{
+		results: map[string]URLResult{},
+		retries: map[string]int{},
+		errors:  map[string]int{},
+	}
+}
+
+// record stores the latest result for a URL and accumulates retry/error
+// counters across monitor iterations.
+func (m *urlMetrics) record(result URLResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.results[result.URL] = result
+	if len(result.Attempts) > 1 {
+		m.retries[result.URL] += len(result.Attempts) - 1
+	}
+	if result.Error != "" {
+		m.errors[result.URL]++
+	}
+}
+
+// metricLabel escapes a label value for Prometheus text exposition format.
+func metricLabel(v string) string {
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `"`, `\"`)
+	v = strings.ReplaceAll(v, "\n", `\n`)
+	return v
+}
+
+// WriteTo renders all recorded results as Prometheus text format.
+func (m *urlMetrics) WriteTo(w http.ResponseWriter) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	fmt.Fprintln(w, "# HELP urlcheck_up Whether the last check of the URL succeeded (1) or not (0)")
+	fmt.Fprintln(w, "# TYPE urlcheck_up gauge")
+	for u, r := range m.results {
+		up := 0
+		if r.Error == "" {
+			up = 1
+		}
+		fmt.Fprintf(w, "urlcheck_up{url=\"%s\"} %d\n", metricLabel(u), up)
+	}
+
+	fmt.Fprintln(w, "# HELP urlcheck_response_seconds Response time of the last check in seconds")
+	fmt.Fprintln(w, "# TYPE urlcheck_response_seconds gauge")
+	for u, r := range m.results {
+		fmt.Fprintf(w, "urlcheck_response_seconds{url=\"%s\"} %f\n", metricLabel(u), r.ResponseTime.Seconds())
+	}
+
+	fmt.Fprintln(w, "# HELP urlcheck_status_code HTTP status code of the last check")
+	fmt.Fprintln(w, "# TYPE urlcheck_status_code gauge")
+	for u, r := range m.results {
+		fmt.Fprintf(w, "urlcheck_status_code{url=\"%s\"} %d\n", metricLabel(u), r.StatusCode)
+	}
+
+	fmt.Fprintln(w, "# HELP urlcheck_keyword_found Whether the configured keyword was found in the last check (1) or not (0)")
+	fmt.Fprintln(w, "# TYPE urlcheck_keyword_found gauge")
+	for u, r := range m.results {
+		found := 0
+		if r.KeywordFound {
+			found = 1
+		}
+		fmt.Fprintf(w, "urlcheck_keyword_found{url=\"%s\"} %d\n", metricLabel(u), found)
+	}
+
+	fmt.Fprintln(w, "# HELP urlcheck_retries_total Total retry attempts issued for the URL across all checks")
+	fmt.Fprintln(w, "# TYPE urlcheck_retries_total counter")
+	for u, n := range m.retries {
+		fmt.Fprintf(w, "urlcheck_retries_total{url=\"%s\"} %d\n", metricLabel(u), n)
+	}
+
+	fmt.Fprintln(w, "# HELP urlcheck_errors_total Total failed checks for the URL")
+	fmt.Fprintln(w, "# TYPE urlcheck_errors_total counter")
+	for u, n := range m.errors {
+		fmt.Fprintf(w, "urlcheck_errors_total{url=\"%s\"} %d\n", metricLabel(u), n)
+	}
+}
+
+// handler returns an http.Handler serving the metrics in Prometheus text
+// format at the scrape endpoint.
+func (m *urlMetrics) handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		m.WriteTo(w)
+	})
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestURLMetricsRecord(t *testing.T) {
+	m := newURLMetrics()
+
+	m.record(URLResult{URL: "https://example.com/", StatusCode: 200, KeywordFound: true})
+	m.record(URLResult{URL: "https://example.com/", StatusCode: 503, Error: "server error status 503",
+		Attempts: []AttemptRecord{{Attempt: 1}, {Attempt: 2}, {Attempt: 3}}})
+
+	if got := m.results["https://example.com/"].StatusCode; got != 503 {
+		t.Errorf("latest recorded status = %d, want 503", got)
+	}
+	if got := m.retries["https://example.com/"]; got != 2 {
+		t.Errorf("retries = %d, want 2", got)
+	}
+	if got := m.errors["https://example.com/"]; got != 1 {
+		t.Errorf("errors = %d, want 1", got)
+	}
+}
+
+func TestURLMetricsRecordNoRetriesOnSingleAttempt(t *testing.T) {
+	m := newURLMetrics()
+	m.record(URLResult{URL: "https://example.com/", StatusCode: 200, Attempts: []AttemptRecord{{Attempt: 1}}})
+	if got := m.retries["https://example.com/"]; got != 0 {
+		t.Errorf("retries = %d, want 0 for a single attempt", got)
+	}
+}
+
+func TestMetricLabel(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`plain`, `plain`},
+		{`has "quotes"`, `has \"quotes\"`},
+		{`back\slash`, `back\\slash`},
+		{"multi\nline", `multi\nline`},
+	}
+	for _, tt := range tests {
+		if got := metricLabel(tt.in); got != tt.want {
+			t.Errorf("metricLabel(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestURLMetricsWriteTo(t *testing.T) {
+	m := newURLMetrics()
+	m.record(URLResult{
+		URL:          "https://example.com/",
+		StatusCode:   200,
+		ResponseTime: 50 * time.Millisecond,
+		KeywordFound: true,
+	})
+
+	rec := httptest.NewRecorder()
+	m.WriteTo(rec)
+	body := rec.Body.String()
+
+	for _, want := range []string{
+		`urlcheck_up{url="https://example.com/"} 1`,
+		`urlcheck_status_code{url="https://example.com/"} 200`,
+		`urlcheck_keyword_found{url="https://example.com/"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("WriteTo() output missing %q, got:\n%s", want, body)
+		}
+	}
+
+	// retries/errors counters are only emitted once incremented, so a clean
+	// single-attempt success leaves both series absent rather than zeroed.
+	if strings.Contains(body, "urlcheck_retries_total{") || strings.Contains(body, "urlcheck_errors_total{") {
+		t.Errorf("expected no retries/errors series for an untouched URL, got:\n%s", body)
+	}
+}
+
+func TestURLMetricsWriteToMarksDownOnError(t *testing.T) {
+	m := newURLMetrics()
+	m.record(URLResult{URL: "https://example.com/", Error: "connection refused"})
+
+	rec := httptest.NewRecorder()
+	m.WriteTo(rec)
+
+	if !strings.Contains(rec.Body.String(), `urlcheck_up{url="https://example.com/"} 0`) {
+		t.Errorf("expected urlcheck_up to be 0 for a failed check, got:\n%s", rec.Body.String())
+	}
+}
+
+func TestURLMetricsHandlerSetsContentType(t *testing.T) {
+	m := newURLMetrics()
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/metrics", nil)
+
+	m.handler().ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Content-Type"); got != "text/plain; version=0.0.4" {
+		t.Errorf("Content-Type = %q, want %q", got, "text/plain; version=0.0.4")
+	}
+}
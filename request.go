@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// parseHeader splits a "K: V" --header flag value into a key/value pair.
+func parseHeader(raw string) (string, string, error) {
+	parts := strings.SplitN(raw, ":", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid header %q, want \"Key: Value\"", raw)
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), nil
+}
+
+// newRequest builds an *http.Request for rawURL using the checker's method,
+// headers, user agent and cache-conditional headers, so checkURL and crawl
+// share one place that assembles outgoing requests.
+func (c *URLChecker) newRequest(rawURL, method string) (*http.Request, error) {
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequest(method, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range c.headers {
+		key, value, err := parseHeader(h)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(key, value)
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	c.applyConditionalHeaders(req, rawURL)
+	return req, nil
+}
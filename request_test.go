@@ -0,0 +1,96 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParseHeader(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       string
+		wantKey   string
+		wantValue string
+		wantErr   bool
+	}{
+		{"simple", "X-Foo: bar", "X-Foo", "bar", false},
+		{"extra whitespace trimmed", "  X-Foo  :  bar  ", "X-Foo", "bar", false},
+		{"value containing colon", "Authorization: Bearer abc:def", "Authorization", "Bearer abc:def", false},
+		{"missing colon", "X-Foo bar", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, value, err := parseHeader(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseHeader(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if key != tt.wantKey || value != tt.wantValue {
+				t.Errorf("parseHeader(%q) = (%q, %q), want (%q, %q)", tt.raw, key, value, tt.wantKey, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestNewRequestDefaultsToGet(t *testing.T) {
+	c := &URLChecker{}
+	req, err := c.newRequest("https://example.com/", "")
+	if err != nil {
+		t.Fatalf("newRequest: %v", err)
+	}
+	if req.Method != http.MethodGet {
+		t.Errorf("Method = %q, want %q", req.Method, http.MethodGet)
+	}
+}
+
+func TestNewRequestUsesGivenMethod(t *testing.T) {
+	c := &URLChecker{}
+	req, err := c.newRequest("https://example.com/", http.MethodHead)
+	if err != nil {
+		t.Fatalf("newRequest: %v", err)
+	}
+	if req.Method != http.MethodHead {
+		t.Errorf("Method = %q, want %q", req.Method, http.MethodHead)
+	}
+}
+
+func TestNewRequestAppliesHeadersAndUserAgent(t *testing.T) {
+	c := &URLChecker{
+		headers:   []string{"X-Foo: bar", "X-Baz: qux"},
+		userAgent: "urlchecker-test/1.0",
+	}
+	req, err := c.newRequest("https://example.com/", "")
+	if err != nil {
+		t.Fatalf("newRequest: %v", err)
+	}
+	if got := req.Header.Get("X-Foo"); got != "bar" {
+		t.Errorf("X-Foo = %q, want %q", got, "bar")
+	}
+	if got := req.Header.Get("X-Baz"); got != "qux" {
+		t.Errorf("X-Baz = %q, want %q", got, "qux")
+	}
+	if got := req.Header.Get("User-Agent"); got != "urlchecker-test/1.0" {
+		t.Errorf("User-Agent = %q, want %q", got, "urlchecker-test/1.0")
+	}
+}
+
+func TestNewRequestInvalidHeaderErrors(t *testing.T) {
+	c := &URLChecker{headers: []string{"not-a-header"}}
+	if _, err := c.newRequest("https://example.com/", ""); err == nil {
+		t.Error("expected error for a malformed --header value")
+	}
+}
+
+func TestNewRequestNoUserAgentLeavesDefault(t *testing.T) {
+	c := &URLChecker{}
+	req, err := c.newRequest("https://example.com/", "")
+	if err != nil {
+		t.Fatalf("newRequest: %v", err)
+	}
+	if req.Header.Get("User-Agent") != "" {
+		t.Error("expected no User-Agent header when unset")
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AttemptRecord captures the outcome of a single request attempt, so retry
+// behavior is visible in the CSV/JSON output instead of only the final result.
+type AttemptRecord struct {
+	Attempt      int           `json:"attempt"`
+	StatusCode   int           `json:"status_code"`
+	ResponseTime time.Duration `json:"response_time"`
+	Error        string        `json:"error"`
+}
+
+// backoffMode selects how long to wait between retry attempts.
+type backoffMode string
+
+const (
+	backoffFixed       backoffMode = "fixed"
+	backoffLinear      backoffMode = "linear"
+	backoffExponential backoffMode = "exponential"
+)
+
+// parseBackoffMode validates a --retry-backoff value.
+func parseBackoffMode(s string) (backoffMode, error) {
+	switch backoffMode(s) {
+	case backoffFixed, backoffLinear, backoffExponential:
+		return backoffMode(s), nil
+	default:
+		return "", fmt.Errorf("invalid retry-backoff mode %q (want fixed, linear, or exponential)", s)
+	}
+}
+
+// retryDelay computes how long to sleep before the given attempt (0-indexed)
+// for the configured backoff mode, with optional jitter to avoid thundering
+// herds across many URLs retrying in lockstep.
+func retryDelay(mode backoffMode, base time.Duration, attempt int, jitter bool) time.Duration {
+	var delay time.Duration
+	switch mode {
+	case backoffLinear:
+		delay = base * time.Duration(attempt+1)
+	case backoffExponential:
+		delay = base * time.Duration(1<<uint(attempt))
+	default: // fixed
+		delay = base
+	}
+	if jitter && delay > 0 {
+		delay = time.Duration(float64(delay) * (0.5 + rand.Float64()))
+	}
+	return delay
+}
+
+// retryReason classifies why a response should be retried, matching one of
+// the caller-supplied --retry-on tokens (5xx, timeout, invalid-body).
+type retryReason string
+
+const (
+	retryOnTransport   retryReason = "timeout"
+	retryOnServerError retryReason = "5xx"
+	retryOnInvalidBody retryReason = "invalid-body"
+)
+
+// retryOnSet parses a comma-separated --retry-on list into a lookup set.
+type retryOnSet map[retryReason]bool
+
+func parseRetryOn(s string) retryOnSet {
+	set := retryOnSet{}
+	for _, tok := range strings.Split(s, ",") {
+		tok = strings.TrimSpace(tok)
+		if tok != "" {
+			set[retryReason(tok)] = true
+		}
+	}
+	return set
+}
+
+func (s retryOnSet) allows(r retryReason) bool {
+	return s[r]
+}
+
+// validateContent applies the --expect-regex check used to decide whether a
+// 200-with-garbage-body response should be treated as a failure worth
+// retrying, rather than only transport-level errors. --keyword is
+// deliberately excluded: it's a report of whether a term was seen, not a
+// liveness assertion, and folding it in here would burn retries against a
+// perfectly healthy page that simply doesn't mention the term whenever
+// --retry-on invalid-body is enabled.
+func validateContent(body []byte, statusCode int, expectRegex string) error {
+	if statusCode >= 500 {
+		return fmt.Errorf("server error status %d", statusCode)
+	}
+	if expectRegex != "" {
+		re, err := regexp.Compile(expectRegex)
+		if err != nil {
+			return fmt.Errorf("invalid expect-regex: %v", err)
+		}
+		if !re.Match(body) {
+			return fmt.Errorf("response body did not match expect-regex %q", expectRegex)
+		}
+	}
+	return nil
+}
+
+// attemptsSummary renders attempt records as a compact string for CSV cells,
+// e.g. "1:200:12ms;2:503:8ms:server error status 503".
+func attemptsSummary(attempts []AttemptRecord) string {
+	parts := make([]string, len(attempts))
+	for i, a := range attempts {
+		if a.Error != "" {
+			parts[i] = strconv.Itoa(a.Attempt) + ":" + strconv.Itoa(a.StatusCode) + ":" + a.ResponseTime.String() + ":" + a.Error
+		} else {
+			parts[i] = strconv.Itoa(a.Attempt) + ":" + strconv.Itoa(a.StatusCode) + ":" + a.ResponseTime.String()
+		}
+	}
+	return strings.Join(parts, ";")
+}
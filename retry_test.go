@@ -0,0 +1,143 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBackoffMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    backoffMode
+		wantErr bool
+	}{
+		{"fixed", "fixed", backoffFixed, false},
+		{"linear", "linear", backoffLinear, false},
+		{"exponential", "exponential", backoffExponential, false},
+		{"invalid", "bogus", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBackoffMode(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBackoffMode(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseBackoffMode(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	base := 100 * time.Millisecond
+
+	tests := []struct {
+		name    string
+		mode    backoffMode
+		attempt int
+		want    time.Duration
+	}{
+		{"fixed attempt 0", backoffFixed, 0, base},
+		{"fixed attempt 2", backoffFixed, 2, base},
+		{"linear attempt 0", backoffLinear, 0, base},
+		{"linear attempt 2", backoffLinear, 2, 3 * base},
+		{"exponential attempt 0", backoffExponential, 0, base},
+		{"exponential attempt 2", backoffExponential, 2, 4 * base},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryDelay(tt.mode, base, tt.attempt, false); got != tt.want {
+				t.Errorf("retryDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelayJitterStaysInBounds(t *testing.T) {
+	base := 100 * time.Millisecond
+	for i := 0; i < 50; i++ {
+		got := retryDelay(backoffFixed, base, 0, true)
+		if got < base/2 || got > base*3/2 {
+			t.Fatalf("retryDelay() with jitter = %v, want in [%v, %v]", got, base/2, base*3/2)
+		}
+	}
+}
+
+func TestRetryDelayNoJitterWhenZero(t *testing.T) {
+	if got := retryDelay(backoffFixed, 0, 0, true); got != 0 {
+		t.Errorf("retryDelay() with zero base and jitter = %v, want 0", got)
+	}
+}
+
+func TestParseRetryOnAndAllows(t *testing.T) {
+	set := parseRetryOn(" 5xx ,invalid-body,, timeout")
+	for _, r := range []retryReason{retryOnServerError, retryOnInvalidBody, retryOnTransport} {
+		if !set.allows(r) {
+			t.Errorf("expected retryOnSet to allow %q", r)
+		}
+	}
+	if set.allows("bogus") {
+		t.Error("expected retryOnSet to reject an unlisted reason")
+	}
+}
+
+func TestParseRetryOnEmpty(t *testing.T) {
+	set := parseRetryOn("")
+	if set.allows(retryOnServerError) {
+		t.Error("expected empty --retry-on to allow nothing")
+	}
+}
+
+func TestValidateContent(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		statusCode  int
+		expectRegex string
+		wantErr     bool
+	}{
+		{"5xx always fails regardless of regex", "ok", 503, "", true},
+		{"200 with no regex passes", "anything", 200, "", false},
+		{"regex match passes", "hello world", 200, "hello", false},
+		{"regex mismatch fails", "goodbye", 200, "hello", true},
+		{"invalid regex fails", "x", 200, "(", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateContent([]byte(tt.body), tt.statusCode, tt.expectRegex)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateContent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateContentIgnoresKeyword(t *testing.T) {
+	// A keyword miss on a healthy 200 must never surface as an error here -
+	// validateContent no longer takes a keyword argument at all.
+	if err := validateContent([]byte("no match in here"), 200, ""); err != nil {
+		t.Errorf("validateContent() = %v, want nil for a plain keyword-less 200", err)
+	}
+}
+
+func TestAttemptsSummary(t *testing.T) {
+	attempts := []AttemptRecord{
+		{Attempt: 1, StatusCode: 503, ResponseTime: 8 * time.Millisecond, Error: "server error status 503"},
+		{Attempt: 2, StatusCode: 200, ResponseTime: 12 * time.Millisecond},
+	}
+	want := "1:503:8ms:server error status 503;2:200:12ms"
+	if got := attemptsSummary(attempts); got != want {
+		t.Errorf("attemptsSummary() = %q, want %q", got, want)
+	}
+}
+
+func TestAttemptsSummaryEmpty(t *testing.T) {
+	if got := attemptsSummary(nil); got != "" {
+		t.Errorf("attemptsSummary(nil) = %q, want empty string", got)
+	}
+}
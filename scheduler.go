@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// hostGate bounds concurrency and enforces a minimum delay between
+// consecutive requests to a single host, so a large URL list doesn't hammer
+// any one server even when the global worker pool is large.
+type hostGate struct {
+	sem   chan struct{}
+	mu    sync.Mutex
+	last  time.Time
+	delay time.Duration
+}
+
+func newHostGate(maxPerHost int, delay time.Duration) *hostGate {
+	return &hostGate{sem: make(chan struct{}, maxPerHost), delay: delay}
+}
+
+func (g *hostGate) acquire() {
+	g.sem <- struct{}{}
+	// Hold the lock across the sleep so concurrent acquirers for the same
+	// host wait on each other rather than all computing their delay from
+	// the same stale g.last and firing together.
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if wait := g.delay - time.Since(g.last); wait > 0 {
+		time.Sleep(wait)
+	}
+	g.last = time.Now()
+}
+
+func (g *hostGate) release() {
+	<-g.sem
+}
+
+// hostOf extracts the host to serialize on; URLs that fail to parse are
+// left ungated (checkURL will report the parse error itself).
+func hostOf(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Host
+}
+
+// checkJob is one unit of work for the shared worker pool. checker defaults
+// to the pool owner but can be a per-job clone (e.g. checkTargets layering
+// per-target validators onto the shared checker), and err lets a caller that
+// already failed to prepare a job (e.g. an invalid per-target validator
+// spec) surface that as a result without ever making a request.
+type checkJob struct {
+	url     string
+	keyword string
+	checker *URLChecker
+	err     error
+}
+
+// checkURLs checks multiple URLs through a bounded worker pool
+// (--concurrency), an optional global rate limiter (--rps), and per-host
+// politeness (--max-per-host, --per-host-delay), instead of spawning one
+// goroutine and one connection per URL. Result ordering is preserved by
+// pre-allocating the results slice and having each worker write to its
+// assigned index.
+func (c *URLChecker) checkURLs(urls []string, keyword string) []URLResult {
+	jobs := make([]checkJob, len(urls))
+	for i, rawURL := range urls {
+		jobs[i] = checkJob{url: rawURL, keyword: keyword}
+	}
+	return c.runJobs(jobs)
+}
+
+// runJobs is the shared scheduling core behind checkURLs and checkTargets:
+// every job goes through the same bounded pool, global rate limiter, and
+// per-host gate, regardless of which *URLChecker (if any override is set)
+// actually performs the request.
+func (c *URLChecker) runJobs(jobs []checkJob) []URLResult {
+	results := make([]URLResult, len(jobs))
+
+	concurrency := c.concurrency
+	if concurrency <= 0 {
+		concurrency = len(jobs)
+	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+
+	var limiter *rate.Limiter
+	if c.rps > 0 {
+		limiter = rate.NewLimiter(rate.Limit(c.rps), 1)
+	}
+
+	maxPerHost := c.maxPerHost
+	if maxPerHost <= 0 {
+		maxPerHost = concurrency
+	}
+	var hostMu sync.Mutex
+	hostGates := map[string]*hostGate{}
+	gateFor := func(host string) *hostGate {
+		hostMu.Lock()
+		defer hostMu.Unlock()
+		g, ok := hostGates[host]
+		if !ok {
+			g = newHostGate(maxPerHost, c.perHostDelay)
+			hostGates[host] = g
+		}
+		return g
+	}
+
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		if job.err != nil {
+			results[i] = URLResult{URL: job.url, Error: job.err.Error()}
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(idx int, j checkJob) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if limiter != nil {
+				limiter.Wait(context.Background())
+			}
+
+			if host := hostOf(j.url); host != "" {
+				gate := gateFor(host)
+				gate.acquire()
+				defer gate.release()
+			}
+
+			checker := j.checker
+			if checker == nil {
+				checker = c
+			}
+			results[idx] = checker.checkURL(j.url, j.keyword)
+		}(i, job)
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errBadTarget = errors.New("invalid target")
+
+func TestHostGateEnforcesDelay(t *testing.T) {
+	gate := newHostGate(1, 30*time.Millisecond)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			gate.acquire()
+			defer gate.release()
+		}()
+	}
+	wg.Wait()
+
+	// Three acquisitions serialized through a single-slot gate with a
+	// 30ms delay must take at least 2*delay end to end.
+	if elapsed := time.Since(start); elapsed < 2*30*time.Millisecond {
+		t.Errorf("three gated acquisitions took %v, want at least %v", elapsed, 2*30*time.Millisecond)
+	}
+}
+
+func TestHostGateAllowsMaxPerHostConcurrently(t *testing.T) {
+	gate := newHostGate(2, 0)
+
+	var inFlight, maxObserved int32
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			gate.acquire()
+			defer gate.release()
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				max := atomic.LoadInt32(&maxObserved)
+				if n <= max || atomic.CompareAndSwapInt32(&maxObserved, max, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+		}()
+	}
+	wg.Wait()
+
+	if maxObserved < 2 {
+		t.Errorf("expected both slots to run concurrently, max observed in-flight = %d", maxObserved)
+	}
+}
+
+func TestHostOf(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"http://example.com/path", "example.com"},
+		{"https://example.com:8443/", "example.com:8443"},
+		{"://not a url", ""},
+	}
+	for _, tt := range tests {
+		if got := hostOf(tt.url); got != tt.want {
+			t.Errorf("hostOf(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestRunJobsSurfacesJobErrorsWithoutARequest(t *testing.T) {
+	c := &URLChecker{concurrency: 2}
+	jobs := []checkJob{
+		{url: "http://example.com/bad", err: errBadTarget},
+	}
+
+	results := c.runJobs(jobs)
+
+	if len(results) != 1 || results[0].URL != "http://example.com/bad" {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if results[0].Error != errBadTarget.Error() {
+		t.Errorf("Error = %q, want %q", results[0].Error, errBadTarget.Error())
+	}
+}
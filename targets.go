@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Target describes one URL entry in an extended YAML/JSON input file,
+// letting validators be declared per-URL instead of only globally via
+// flags.
+type Target struct {
+	URL          string   `yaml:"url" json:"url"`
+	Keyword      string   `yaml:"keyword,omitempty" json:"keyword,omitempty"`
+	ExpectStatus string   `yaml:"expect_status,omitempty" json:"expect_status,omitempty"`
+	ExpectRegex  string   `yaml:"expect_regex,omitempty" json:"expect_regex,omitempty"`
+	ExpectJSON   []string `yaml:"expect_json,omitempty" json:"expect_json,omitempty"`
+	ExpectXPath  []string `yaml:"expect_xpath,omitempty" json:"expect_xpath,omitempty"`
+	ExpectHeader []string `yaml:"expect_header,omitempty" json:"expect_header,omitempty"`
+	ExpectSHA256 string   `yaml:"expect_sha256,omitempty" json:"expect_sha256,omitempty"`
+}
+
+// isExtendedTargetsFile reports whether filePath should be parsed as the
+// YAML/JSON target format rather than the plain one-URL-per-line format.
+func isExtendedTargetsFile(filePath string) bool {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// readTargets reads URL targets from a YAML or JSON file. JSON is a subset
+// of YAML, so a single yaml.Unmarshal call handles both.
+func readTargets(filePath string) ([]Target, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var targets []Target
+	if err := yaml.Unmarshal(data, &targets); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", filePath, err)
+	}
+	return targets, nil
+}
+
+// buildValidators constructs the validators declared on this target.
+func (t Target) buildValidators() ([]Validator, error) {
+	var validators []Validator
+	if t.ExpectStatus != "" {
+		validators = append(validators, statusClassValidator{spec: t.ExpectStatus})
+	}
+	if t.ExpectRegex != "" {
+		rv, err := newRegexValidator(t.ExpectRegex)
+		if err != nil {
+			return nil, err
+		}
+		validators = append(validators, rv)
+	}
+	for _, spec := range t.ExpectJSON {
+		jv, err := newJSONPathValidator(spec)
+		if err != nil {
+			return nil, err
+		}
+		validators = append(validators, jv)
+	}
+	for _, expr := range t.ExpectXPath {
+		validators = append(validators, xpathValidator{expr: expr})
+	}
+	for _, spec := range t.ExpectHeader {
+		hv, err := newHeaderValidator(spec)
+		if err != nil {
+			return nil, err
+		}
+		validators = append(validators, hv)
+	}
+	if t.ExpectSHA256 != "" {
+		validators = append(validators, sha256Validator{want: t.ExpectSHA256})
+	}
+	return validators, nil
+}
+
+// checkTargets checks each target through the same bounded worker
+// pool/rate-limiter/host-gate that checkURLs uses, layering its per-target
+// validators on top of the checker's globally configured ones rather than
+// spawning one goroutine and connection per target.
+func (c *URLChecker) checkTargets(targets []Target) []URLResult {
+	jobs := make([]checkJob, len(targets))
+	for i, t := range targets {
+		extra, err := t.buildValidators()
+		if err != nil {
+			jobs[i] = checkJob{url: t.URL, err: err}
+			continue
+		}
+		targetChecker := *c
+		targetChecker.validators = append(append([]Validator{}, c.validators...), extra...)
+		jobs[i] = checkJob{url: t.URL, keyword: t.Keyword, checker: &targetChecker}
+	}
+	return c.runJobs(jobs)
+}
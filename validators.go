@@ -0,0 +1,238 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PaesslerAG/jsonpath"
+	"github.com/antchfx/htmlquery"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationResult is the outcome of running a single Validator against a
+// response, recorded on URLResult so the CSV/JSON output shows exactly
+// which assertions passed or failed.
+type ValidationResult struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+// Validator generalizes the old single keyword check into a composable
+// content assertion. All configured validators must pass for a URL to be
+// considered healthy.
+type Validator interface {
+	Name() string
+	Validate(resp *http.Response, body []byte) ValidationResult
+	// NeedsBody reports whether this validator inspects the response body,
+	// so --fast knows it can't downgrade to a bodyless HEAD request.
+	NeedsBody() bool
+}
+
+// runValidators evaluates every validator against a response, short-circuit
+// free (all run, so users see every failure at once rather than the first).
+func runValidators(validators []Validator, resp *http.Response, body []byte) []ValidationResult {
+	results := make([]ValidationResult, 0, len(validators))
+	for _, v := range validators {
+		results = append(results, v.Validate(resp, body))
+	}
+	return results
+}
+
+// validatorsNeedBody reports whether any validator in the set requires a
+// response body to evaluate.
+func validatorsNeedBody(validators []Validator) bool {
+	for _, v := range validators {
+		if v.NeedsBody() {
+			return true
+		}
+	}
+	return false
+}
+
+func allPassed(results []ValidationResult) bool {
+	for _, r := range results {
+		if !r.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// validationsSummary renders validation results as a compact CSV cell, e.g.
+// "expect-status:pass;expect-regex:fail:body did not match ...".
+func validationsSummary(results []ValidationResult) string {
+	parts := make([]string, len(results))
+	for i, r := range results {
+		status := "pass"
+		if !r.Passed {
+			status = "fail"
+		}
+		if r.Message != "" {
+			parts[i] = r.Name + ":" + status + ":" + r.Message
+		} else {
+			parts[i] = r.Name + ":" + status
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+// statusClassValidator implements --expect-status, e.g. "2xx,301".
+type statusClassValidator struct {
+	spec string
+}
+
+func (v statusClassValidator) Name() string { return "expect-status" }
+
+func (v statusClassValidator) NeedsBody() bool { return false }
+
+func (v statusClassValidator) Validate(resp *http.Response, body []byte) ValidationResult {
+	for _, tok := range strings.Split(v.spec, ",") {
+		tok = strings.TrimSpace(tok)
+		if tok == "" {
+			continue
+		}
+		if strings.HasSuffix(tok, "xx") {
+			class, err := strconv.Atoi(strings.TrimSuffix(tok, "xx"))
+			if err == nil && resp.StatusCode/100 == class {
+				return ValidationResult{Name: v.Name(), Passed: true}
+			}
+			continue
+		}
+		if code, err := strconv.Atoi(tok); err == nil && resp.StatusCode == code {
+			return ValidationResult{Name: v.Name(), Passed: true}
+		}
+	}
+	return ValidationResult{Name: v.Name(), Passed: false, Message: fmt.Sprintf("status %d not in %q", resp.StatusCode, v.spec)}
+}
+
+// regexValidator implements --expect-regex.
+type regexValidator struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+func newRegexValidator(pattern string) (*regexValidator, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expect-regex: %v", err)
+	}
+	return &regexValidator{pattern: pattern, re: re}, nil
+}
+
+func (v *regexValidator) Name() string { return "expect-regex" }
+
+func (v *regexValidator) NeedsBody() bool { return true }
+
+func (v *regexValidator) Validate(resp *http.Response, body []byte) ValidationResult {
+	if v.re.Match(body) {
+		return ValidationResult{Name: v.Name(), Passed: true}
+	}
+	return ValidationResult{Name: v.Name(), Passed: false, Message: fmt.Sprintf("body did not match %q", v.pattern)}
+}
+
+// jsonPathValidator implements --expect-json <path>=<value>.
+type jsonPathValidator struct {
+	path  string
+	value string
+}
+
+func newJSONPathValidator(spec string) (*jsonPathValidator, error) {
+	path, value, ok := strings.Cut(spec, "=")
+	if !ok {
+		return nil, fmt.Errorf("invalid expect-json %q, want \"<jsonpath>=<value>\"", spec)
+	}
+	return &jsonPathValidator{path: path, value: value}, nil
+}
+
+func (v *jsonPathValidator) Name() string { return "expect-json" }
+
+func (v *jsonPathValidator) NeedsBody() bool { return true }
+
+func (v *jsonPathValidator) Validate(resp *http.Response, body []byte) ValidationResult {
+	var parsed interface{}
+	if err := yaml.Unmarshal(body, &parsed); err != nil {
+		return ValidationResult{Name: v.Name(), Passed: false, Message: fmt.Sprintf("response is not valid JSON: %v", err)}
+	}
+	got, err := jsonpath.Get(v.path, parsed)
+	if err != nil {
+		return ValidationResult{Name: v.Name(), Passed: false, Message: fmt.Sprintf("jsonpath %q: %v", v.path, err)}
+	}
+	if fmt.Sprintf("%v", got) != v.value {
+		return ValidationResult{Name: v.Name(), Passed: false, Message: fmt.Sprintf("jsonpath %q = %v, want %v", v.path, got, v.value)}
+	}
+	return ValidationResult{Name: v.Name(), Passed: true}
+}
+
+// xpathValidator implements --expect-xpath, asserting the expression
+// matches at least one node in the HTML document.
+type xpathValidator struct {
+	expr string
+}
+
+func (v xpathValidator) Name() string { return "expect-xpath" }
+
+func (v xpathValidator) NeedsBody() bool { return true }
+
+func (v xpathValidator) Validate(resp *http.Response, body []byte) ValidationResult {
+	doc, err := htmlquery.Parse(strings.NewReader(string(body)))
+	if err != nil {
+		return ValidationResult{Name: v.Name(), Passed: false, Message: fmt.Sprintf("parsing HTML: %v", err)}
+	}
+	node, err := htmlquery.Query(doc, v.expr)
+	if err != nil {
+		return ValidationResult{Name: v.Name(), Passed: false, Message: fmt.Sprintf("invalid xpath %q: %v", v.expr, err)}
+	}
+	if node == nil {
+		return ValidationResult{Name: v.Name(), Passed: false, Message: fmt.Sprintf("xpath %q matched no nodes", v.expr)}
+	}
+	return ValidationResult{Name: v.Name(), Passed: true}
+}
+
+// headerValidator implements --expect-header "X-Foo: bar".
+type headerValidator struct {
+	key, value string
+}
+
+func newHeaderValidator(spec string) (*headerValidator, error) {
+	key, value, err := parseHeader(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expect-header: %v", err)
+	}
+	return &headerValidator{key: key, value: value}, nil
+}
+
+func (v *headerValidator) Name() string { return "expect-header" }
+
+func (v *headerValidator) NeedsBody() bool { return false }
+
+func (v *headerValidator) Validate(resp *http.Response, body []byte) ValidationResult {
+	got := resp.Header.Get(v.key)
+	if got == v.value {
+		return ValidationResult{Name: v.Name(), Passed: true}
+	}
+	return ValidationResult{Name: v.Name(), Passed: false, Message: fmt.Sprintf("header %s = %q, want %q", v.key, got, v.value)}
+}
+
+// sha256Validator implements --expect-sha256 <hex>, a content hash check.
+type sha256Validator struct {
+	want string
+}
+
+func (v sha256Validator) Name() string { return "expect-sha256" }
+
+func (v sha256Validator) NeedsBody() bool { return true }
+
+func (v sha256Validator) Validate(resp *http.Response, body []byte) ValidationResult {
+	sum := sha256.Sum256(body)
+	got := hex.EncodeToString(sum[:])
+	if strings.EqualFold(got, v.want) {
+		return ValidationResult{Name: v.Name(), Passed: true}
+	}
+	return ValidationResult{Name: v.Name(), Passed: false, Message: fmt.Sprintf("sha256 %s, want %s", got, v.want)}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestStatusClassValidator(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       string
+		statusCode int
+		wantPassed bool
+	}{
+		{"2xx class matches 200", "2xx", 200, true},
+		{"2xx class matches 204", "2xx", 204, true},
+		{"2xx class rejects 404", "2xx", 404, false},
+		{"exact code matches", "301", 301, true},
+		{"exact code rejects mismatch", "301", 302, false},
+		{"comma list matches second token", "2xx,301", 301, true},
+		{"comma list rejects unlisted code", "2xx,301", 404, false},
+		{"whitespace around tokens is ignored", " 2xx , 301 ", 301, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := statusClassValidator{spec: tt.spec}
+			resp := &http.Response{StatusCode: tt.statusCode}
+			result := v.Validate(resp, nil)
+			if result.Passed != tt.wantPassed {
+				t.Errorf("Validate(%d) with spec %q = %v, want %v", tt.statusCode, tt.spec, result.Passed, tt.wantPassed)
+			}
+		})
+	}
+}
+
+func TestRegexValidator(t *testing.T) {
+	v, err := newRegexValidator(`^ok$`)
+	if err != nil {
+		t.Fatalf("newRegexValidator: %v", err)
+	}
+	if !v.Validate(&http.Response{}, []byte("ok")).Passed {
+		t.Error("expected matching body to pass")
+	}
+	if v.Validate(&http.Response{}, []byte("not ok")).Passed {
+		t.Error("expected non-matching body to fail")
+	}
+}
+
+func TestNewRegexValidatorInvalidPattern(t *testing.T) {
+	if _, err := newRegexValidator("("); err == nil {
+		t.Error("expected error for invalid regex pattern")
+	}
+}
+
+func TestJSONPathValidator(t *testing.T) {
+	v, err := newJSONPathValidator("$.ok=true")
+	if err != nil {
+		t.Fatalf("newJSONPathValidator: %v", err)
+	}
+	if !v.Validate(&http.Response{}, []byte(`{"ok": true}`)).Passed {
+		t.Error("expected matching JSON to pass")
+	}
+	if v.Validate(&http.Response{}, []byte(`{"ok": false}`)).Passed {
+		t.Error("expected mismatched JSON value to fail")
+	}
+	if v.Validate(&http.Response{}, []byte(`not json`)).Passed {
+		t.Error("expected invalid JSON body to fail")
+	}
+}
+
+func TestNewJSONPathValidatorInvalidSpec(t *testing.T) {
+	if _, err := newJSONPathValidator("$.ok"); err == nil {
+		t.Error("expected error for spec missing '='")
+	}
+}
+
+func TestHeaderValidator(t *testing.T) {
+	v, err := newHeaderValidator("X-Foo: bar")
+	if err != nil {
+		t.Fatalf("newHeaderValidator: %v", err)
+	}
+	resp := &http.Response{Header: http.Header{"X-Foo": []string{"bar"}}}
+	if !v.Validate(resp, nil).Passed {
+		t.Error("expected matching header to pass")
+	}
+	resp = &http.Response{Header: http.Header{"X-Foo": []string{"baz"}}}
+	if v.Validate(resp, nil).Passed {
+		t.Error("expected mismatched header to fail")
+	}
+}
+
+func TestSHA256Validator(t *testing.T) {
+	// sha256("hello") = 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824
+	v := sha256Validator{want: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"}
+	if !v.Validate(&http.Response{}, []byte("hello")).Passed {
+		t.Error("expected matching sha256 to pass")
+	}
+	if v.Validate(&http.Response{}, []byte("world")).Passed {
+		t.Error("expected mismatched sha256 to fail")
+	}
+}
+
+func TestValidatorsNeedBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		validators []Validator
+		want       bool
+	}{
+		{"empty set", nil, false},
+		{"status and header only", []Validator{statusClassValidator{spec: "2xx"}, &headerValidator{key: "X-Foo", value: "bar"}}, false},
+		{"regex needs body", []Validator{statusClassValidator{spec: "2xx"}, &regexValidator{pattern: "ok"}}, true},
+		{"sha256 needs body", []Validator{sha256Validator{want: "abc"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validatorsNeedBody(tt.validators); got != tt.want {
+				t.Errorf("validatorsNeedBody() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllPassed(t *testing.T) {
+	if !allPassed(nil) {
+		t.Error("expected no validations to be trivially all-passed")
+	}
+	if !allPassed([]ValidationResult{{Passed: true}, {Passed: true}}) {
+		t.Error("expected all-passing results to be all-passed")
+	}
+	if allPassed([]ValidationResult{{Passed: true}, {Passed: false}}) {
+		t.Error("expected one failing result to fail allPassed")
+	}
+}